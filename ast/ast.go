@@ -0,0 +1,118 @@
+// Package ast defines the abstract syntax tree produced by the parser.
+package ast
+
+import "github.com/SlxvkVel/Confighomework/token"
+
+// Node is any node in the AST.
+type Node interface {
+	Pos() token.Position
+}
+
+// Decl is a top-level declaration: a constant, a section header, a
+// key/value pair, or an include directive.
+type Decl interface {
+	Node
+	declNode()
+}
+
+// Expr is anything that evaluates to a value: a literal, a variable
+// reference, or a call to a builtin operator.
+type Expr interface {
+	Node
+	exprNode()
+}
+
+// File is the root of a parsed configuration file.
+type File struct {
+	Name  string
+	Decls []Decl
+}
+
+// ConstDecl is `NAME := value;`.
+type ConstDecl struct {
+	Name    string
+	Value   Expr
+	DeclPos token.Position
+}
+
+func (d *ConstDecl) Pos() token.Position { return d.DeclPos }
+func (d *ConstDecl) declNode()           {}
+
+// SectionHeader is `[name]`.
+type SectionHeader struct {
+	Name    string
+	DeclPos token.Position
+}
+
+func (s *SectionHeader) Pos() token.Position { return s.DeclPos }
+func (s *SectionHeader) declNode()           {}
+
+// KeyValue is `key = value` within a section.
+type KeyValue struct {
+	Key     string
+	Value   Expr
+	DeclPos token.Position
+}
+
+func (k *KeyValue) Pos() token.Position { return k.DeclPos }
+func (k *KeyValue) declNode()           {}
+
+// IncludeDecl is `include @"path";`. It is resolved and spliced into the
+// surrounding file by the parser before the emitter ever sees it.
+type IncludeDecl struct {
+	Path    string
+	DeclPos token.Position
+}
+
+func (i *IncludeDecl) Pos() token.Position { return i.DeclPos }
+func (i *IncludeDecl) declNode()           {}
+
+// Ident is a reference to a constant, e.g. `HOST` or `PORTS`.
+type Ident struct {
+	Name    string
+	ExprPos token.Position
+}
+
+func (i *Ident) Pos() token.Position { return i.ExprPos }
+func (i *Ident) exprNode()           {}
+
+// StringLit is `@"..."`.
+type StringLit struct {
+	Value   string
+	ExprPos token.Position
+}
+
+func (s *StringLit) Pos() token.Position { return s.ExprPos }
+func (s *StringLit) exprNode()           {}
+
+// NumberLit is a decimal or octal integer literal.
+type NumberLit struct {
+	Value   int64
+	Octal   bool
+	ExprPos token.Position
+}
+
+func (n *NumberLit) Pos() token.Position { return n.ExprPos }
+func (n *NumberLit) exprNode()           {}
+
+// ArrayLit is `[ a, b, c ]`.
+type ArrayLit struct {
+	Elems   []Expr
+	ExprPos token.Position
+}
+
+func (a *ArrayLit) Pos() token.Position { return a.ExprPos }
+func (a *ArrayLit) exprNode()           {}
+
+// CallExpr is a constant expression `?( op arg1 arg2 ... )`. Op is one of
+// the arithmetic operators (+, -, *, /, mod) or a builtin function name
+// (ord, chr, abs, min, max, len, concat). Args may themselves be CallExpr
+// nodes, allowing arbitrary nesting.
+type CallExpr struct {
+	Op      string
+	Args    []Expr
+	ExprPos token.Position
+}
+
+func (c *CallExpr) Pos() token.Position { return c.ExprPos }
+func (c *CallExpr) exprNode()           {}
@@ -0,0 +1,82 @@
+// Command confighomework parses a .conf file in this project's
+// configuration language and writes it out as TOML, JSON or YAML.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/SlxvkVel/Confighomework/document"
+	"github.com/SlxvkVel/Confighomework/parser"
+)
+
+func main() {
+	watch := flag.Bool("w", false, "следить за входным файлом и перегенерировать вывод при изменениях")
+	format := flag.String("format", "toml", "формат вывода: toml, json или yaml")
+	flag.Parse()
+
+	if flag.NArg() != 2 {
+		fmt.Println("Использование: confighomework [-w] [-format toml|json|yaml] <input.conf> <output>")
+		return
+	}
+
+	inputPath := flag.Arg(0)
+	outputPath := flag.Arg(1)
+
+	if *watch {
+		if *format != "toml" {
+			fmt.Println("Ошибка: -w поддерживает только -format toml")
+			return
+		}
+		runWatch(parser.NewParser(), inputPath, outputPath)
+		return
+	}
+
+	if err := convert(inputPath, outputPath, *format); err != nil {
+		fmt.Printf("Ошибка: %v\n", err)
+		return
+	}
+
+	fmt.Println("Парсинг завершен успешно.")
+}
+
+func convert(inputPath, outputPath, format string) error {
+	doc, err := document.ParseFile(inputPath)
+	if err != nil {
+		return err
+	}
+
+	out, err := os.Create(outputPath)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	switch format {
+	case "toml":
+		return doc.EncodeTOML(out)
+	case "json":
+		return doc.EncodeJSON(out)
+	case "yaml":
+		return doc.EncodeYAML(out)
+	default:
+		return fmt.Errorf("неизвестный формат вывода: %s", format)
+	}
+}
+
+func runWatch(p *parser.Parser, inputPath, outputPath string) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Printf("Слежение за %s...\n", inputPath)
+	err := p.Watch(ctx, inputPath, outputPath, func(err error) {
+		fmt.Printf("Ошибка: %v\n", err)
+	})
+	if err != nil {
+		fmt.Printf("Ошибка: %v\n", err)
+	}
+}
@@ -0,0 +1,143 @@
+// Package document is the library surface for this project: it turns
+// configuration source into a structured *Document instead of writing
+// TOML straight to disk, so other Go programs can embed the
+// configuration language without shelling out or round-tripping
+// through the filesystem.
+package document
+
+import (
+	"fmt"
+
+	"github.com/SlxvkVel/Confighomework/ast"
+	"github.com/SlxvkVel/Confighomework/parser"
+	"github.com/SlxvkVel/Confighomework/value"
+)
+
+// ErrIncludeNotResolved is returned when a parsed file still contains an
+// `include` directive by the time build runs over it. ParseFile always
+// resolves includes before build sees the file, via Parser.LoadFile; it
+// can only happen when ParseBytes is given source that includes another
+// file, since there is no base file to resolve a relative path against.
+var ErrIncludeNotResolved = fmt.Errorf("include-директива не может быть разрешена без базового файла; используйте ParseFile")
+
+// Value is a configuration value: an int, a string, a bool, or an array
+// of further Values.
+type Value = value.Value
+
+// Entry is a single `key = value` line within a Section.
+type Entry struct {
+	Key   string
+	Value Value
+}
+
+// Section is a `[name]` block and the key/value pairs declared under it,
+// in source order. A key/value pair that appears before any `[section]`
+// header is collected into a leading Section with an empty Name, mirroring
+// how toml.Emitter writes such a key as a bare top-level line.
+type Section struct {
+	Name    string
+	Entries []Entry
+}
+
+// Document is the fully evaluated form of a parsed configuration file:
+// every constant has been folded and every section/key resolved to a
+// concrete Value.
+type Document struct {
+	sections  []Section
+	constants map[string]Value
+}
+
+// Sections returns the document's sections in source order.
+func (d *Document) Sections() []Section {
+	return d.sections
+}
+
+// Constants returns every top-level `NAME := ...;` binding, including
+// ones only used to build other constants.
+func (d *Document) Constants() map[string]Value {
+	return d.constants
+}
+
+// Lookup returns the value of key within section, and whether it was
+// found.
+func (d *Document) Lookup(section, key string) (Value, bool) {
+	for _, s := range d.sections {
+		if s.Name != section {
+			continue
+		}
+		for _, e := range s.Entries {
+			if e.Key == key {
+				return e.Value, true
+			}
+		}
+	}
+	return Value{}, false
+}
+
+// ParseBytes parses src as a standalone configuration source. It
+// returns ErrIncludeNotResolved if src contains an `include` directive,
+// since there is no base file to resolve a relative path against; use
+// ParseFile for sources that may include other files.
+func ParseBytes(src []byte) (*Document, error) {
+	p := parser.NewParser()
+	file, err := parser.ParseFile("<bytes>", src)
+	if err != nil {
+		return nil, err
+	}
+	return build(file, p)
+}
+
+// ParseFile reads and parses path, resolving any `include` directives
+// relative to the files that declare them.
+func ParseFile(path string) (*Document, error) {
+	p := parser.NewParser()
+	file, err := p.LoadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return build(file, p)
+}
+
+// evaluator is the subset of *parser.Parser that build needs: it
+// evaluates expressions against, and extends, a variable scope.
+type evaluator interface {
+	Eval(expr ast.Expr) (Value, error)
+	Define(name string, v Value)
+}
+
+func build(file *ast.File, eval evaluator) (*Document, error) {
+	doc := &Document{constants: make(map[string]Value)}
+
+	var current *Section
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.ConstDecl:
+			v, err := eval.Eval(d.Value)
+			if err != nil {
+				return nil, err
+			}
+			eval.Define(d.Name, v)
+			doc.constants[d.Name] = v
+
+		case *ast.SectionHeader:
+			doc.sections = append(doc.sections, Section{Name: d.Name})
+			current = &doc.sections[len(doc.sections)-1]
+
+		case *ast.KeyValue:
+			v, err := eval.Eval(d.Value)
+			if err != nil {
+				return nil, err
+			}
+			if current == nil {
+				doc.sections = append(doc.sections, Section{})
+				current = &doc.sections[len(doc.sections)-1]
+			}
+			current.Entries = append(current.Entries, Entry{Key: d.Key, Value: v})
+
+		case *ast.IncludeDecl:
+			return nil, ErrIncludeNotResolved
+		}
+	}
+
+	return doc, nil
+}
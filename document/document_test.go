@@ -0,0 +1,159 @@
+package document
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const sampleConf = `
+PORT := 8080;
+HOSTS := [ @"a", @"b" ];
+
+[server]
+port = PORT
+hosts = HOSTS
+`
+
+func TestParseBytes(t *testing.T) {
+	doc, err := ParseBytes([]byte(sampleConf))
+	if err != nil {
+		t.Fatalf("ParseBytes: %v", err)
+	}
+
+	v, ok := doc.Lookup("server", "port")
+	if !ok || v.Int != 8080 {
+		t.Errorf("Lookup(server, port) = %v, %v", v, ok)
+	}
+
+	if got := len(doc.Sections()); got != 1 {
+		t.Errorf("len(Sections()) = %d, want 1", got)
+	}
+
+	if _, ok := doc.Constants()["PORT"]; !ok {
+		t.Errorf("Constants() missing PORT")
+	}
+}
+
+func TestParseBytesRejectsInclude(t *testing.T) {
+	_, err := ParseBytes([]byte(`include @"common.conf";`))
+	if !errors.Is(err, ErrIncludeNotResolved) {
+		t.Errorf("ParseBytes with an include: got %v, want ErrIncludeNotResolved", err)
+	}
+}
+
+func TestParseFileResolvesIncludes(t *testing.T) {
+	dir := t.TempDir()
+	mainPath := filepath.Join(dir, "main.conf")
+	commonPath := filepath.Join(dir, "common.conf")
+
+	if err := os.WriteFile(commonPath, []byte("HOST := @\"localhost\";\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(mainPath, []byte("include @\"common.conf\";\n\n[server]\nhost = HOST\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := ParseFile(mainPath)
+	if err != nil {
+		t.Fatalf("ParseFile: %v", err)
+	}
+
+	v, ok := doc.Lookup("server", "host")
+	if !ok || v.Str != "localhost" {
+		t.Errorf("Lookup(server, host) = %v, %v", v, ok)
+	}
+}
+
+func TestParseBytesAllowsKeyBeforeSection(t *testing.T) {
+	doc, err := ParseBytes([]byte("port = 8080\n\n[server]\nhost = @\"localhost\"\n"))
+	if err != nil {
+		t.Fatalf("ParseBytes: %v", err)
+	}
+
+	v, ok := doc.Lookup("", "port")
+	if !ok || v.Int != 8080 {
+		t.Errorf(`Lookup("", port) = %v, %v`, v, ok)
+	}
+
+	var toml bytes.Buffer
+	if err := doc.EncodeTOML(&toml); err != nil {
+		t.Fatalf("EncodeTOML: %v", err)
+	}
+	want := "port = 8080\n" + "[server]\n" + `host = "localhost"` + "\n"
+	if toml.String() != want {
+		t.Errorf("EncodeTOML:\n%s\nwant:\n%s", toml.String(), want)
+	}
+}
+
+// EncodeTOML writes repeated `[section]` blocks as they appear; EncodeJSON
+// merges them into one object per section name.
+func TestEncodersWithRepeatedSections(t *testing.T) {
+	doc, err := ParseBytes([]byte(`
+[server]
+port = 8080
+
+[other]
+name = @"x"
+
+[server]
+host = @"localhost"
+`))
+	if err != nil {
+		t.Fatalf("ParseBytes: %v", err)
+	}
+
+	var toml bytes.Buffer
+	if err := doc.EncodeTOML(&toml); err != nil {
+		t.Fatalf("EncodeTOML: %v", err)
+	}
+	want := "[server]\n" + "port = 8080\n" + "[other]\n" + `name = "x"` + "\n" + "[server]\n" + `host = "localhost"` + "\n"
+	if toml.String() != want {
+		t.Errorf("EncodeTOML:\n%s\nwant:\n%s", toml.String(), want)
+	}
+
+	var js bytes.Buffer
+	if err := doc.EncodeJSON(&js); err != nil {
+		t.Fatalf("EncodeJSON: %v", err)
+	}
+	if !bytes.Contains(js.Bytes(), []byte(`"port": 8080`)) {
+		t.Errorf("EncodeJSON dropped the first [server] block's entries: %s", js.String())
+	}
+	if !bytes.Contains(js.Bytes(), []byte(`"host": "localhost"`)) {
+		t.Errorf("EncodeJSON missing the second [server] block's entries: %s", js.String())
+	}
+}
+
+func TestEncoders(t *testing.T) {
+	doc, err := ParseBytes([]byte(sampleConf))
+	if err != nil {
+		t.Fatalf("ParseBytes: %v", err)
+	}
+
+	var toml bytes.Buffer
+	if err := doc.EncodeTOML(&toml); err != nil {
+		t.Fatalf("EncodeTOML: %v", err)
+	}
+	want := "[server]\n" + "port = 8080\n" + `hosts = ["a", "b"]` + "\n"
+	if toml.String() != want {
+		t.Errorf("EncodeTOML:\n%s\nwant:\n%s", toml.String(), want)
+	}
+
+	var js bytes.Buffer
+	if err := doc.EncodeJSON(&js); err != nil {
+		t.Fatalf("EncodeJSON: %v", err)
+	}
+	if !bytes.Contains(js.Bytes(), []byte(`"port": 8080`)) {
+		t.Errorf("EncodeJSON missing port field: %s", js.String())
+	}
+
+	var ym bytes.Buffer
+	if err := doc.EncodeYAML(&ym); err != nil {
+		t.Fatalf("EncodeYAML: %v", err)
+	}
+	if !bytes.Contains(ym.Bytes(), []byte("port: 8080")) {
+		t.Errorf("EncodeYAML missing port field: %s", ym.String())
+	}
+}
@@ -0,0 +1,80 @@
+package document
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/SlxvkVel/Confighomework/value"
+)
+
+// EncodeTOML writes the document's sections as TOML.
+func (d *Document) EncodeTOML(w io.Writer) error {
+	for _, s := range d.sections {
+		if s.Name != "" {
+			if _, err := fmt.Fprintf(w, "[%s]\n", s.Name); err != nil {
+				return err
+			}
+		}
+		for _, e := range s.Entries {
+			if _, err := fmt.Fprintf(w, "%s = %s\n", e.Key, e.Value.String()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// EncodeJSON writes the document's sections as a JSON object keyed by
+// section name, each holding an object of its key/value pairs.
+func (d *Document) EncodeJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(d.asNestedMap())
+}
+
+// EncodeYAML writes the document's sections as YAML, with the same
+// shape as EncodeJSON.
+func (d *Document) EncodeYAML(w io.Writer) error {
+	return yaml.NewEncoder(w).Encode(d.asNestedMap())
+}
+
+// asNestedMap merges entries from repeated `[section]` blocks of the same
+// name into a single map entry, the same way Lookup searches across all of
+// them for a key: a map keyed by section name would otherwise silently
+// overwrite and drop an earlier block's entries instead of merging them.
+func (d *Document) asNestedMap() map[string]map[string]interface{} {
+	out := make(map[string]map[string]interface{}, len(d.sections))
+	for _, s := range d.sections {
+		section, ok := out[s.Name]
+		if !ok {
+			section = make(map[string]interface{}, len(s.Entries))
+			out[s.Name] = section
+		}
+		for _, e := range s.Entries {
+			section[e.Key] = toNative(e.Value)
+		}
+	}
+	return out
+}
+
+func toNative(v value.Value) interface{} {
+	switch v.Kind {
+	case value.Int:
+		return v.Int
+	case value.String:
+		return v.Str
+	case value.Bool:
+		return v.Bool
+	case value.Array:
+		out := make([]interface{}, len(v.Array))
+		for i, elem := range v.Array {
+			out[i] = toNative(elem)
+		}
+		return out
+	default:
+		return nil
+	}
+}
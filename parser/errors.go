@@ -0,0 +1,40 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/SlxvkVel/Confighomework/token"
+)
+
+// Sentinel errors. The first three keep their names from before the AST
+// rewrite, but errors are now returned wrapped with a source position,
+// so callers must match them with errors.Is rather than `err ==`.
+var (
+	ErrInvalidSyntax     = fmt.Errorf("неверный синтаксис")
+	ErrUndefinedVariable = fmt.Errorf("неопределенная переменная")
+	ErrInvalidExpression = fmt.Errorf("неверное выражение")
+	ErrArity             = fmt.Errorf("неверное количество аргументов")
+	ErrDivisionByZero    = fmt.Errorf("деление на ноль")
+	ErrIncludeCycle      = fmt.Errorf("циклическое включение файлов")
+)
+
+// posError wraps a sentinel error with the source position it occurred
+// at, e.g. "config.conf:4:9: неверный синтаксис: ожидалось ']'".
+type posError struct {
+	pos      token.Position
+	sentinel error
+	detail   string
+}
+
+func (e *posError) Error() string {
+	if e.detail == "" {
+		return fmt.Sprintf("%s: %s", e.pos, e.sentinel)
+	}
+	return fmt.Sprintf("%s: %s: %s", e.pos, e.sentinel, e.detail)
+}
+
+func (e *posError) Unwrap() error { return e.sentinel }
+
+func newErr(pos token.Position, sentinel error, detail string) error {
+	return &posError{pos: pos, sentinel: sentinel, detail: detail}
+}
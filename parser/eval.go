@@ -0,0 +1,241 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/SlxvkVel/Confighomework/ast"
+	"github.com/SlxvkVel/Confighomework/value"
+)
+
+// Define binds name to v in the parser's variable scope. It implements
+// toml.Resolver.
+func (p *Parser) Define(name string, v value.Value) {
+	p.variables[name] = v
+}
+
+// Eval evaluates expr against the parser's current variable scope. It
+// implements toml.Resolver and is also used internally to fold constant
+// expressions as ConstDecl nodes are encountered.
+func (p *Parser) Eval(expr ast.Expr) (value.Value, error) {
+	switch e := expr.(type) {
+	case *ast.NumberLit:
+		return value.Of(e.Value), nil
+	case *ast.StringLit:
+		return value.OfString(e.Value), nil
+	case *ast.Ident:
+		if v, ok := p.variables[e.Name]; ok {
+			return v, nil
+		}
+		if e.Name == "true" || e.Name == "false" {
+			return value.OfBool(e.Name == "true"), nil
+		}
+		return value.Value{}, newErr(e.ExprPos, ErrUndefinedVariable, e.Name)
+	case *ast.ArrayLit:
+		elems := make([]value.Value, 0, len(e.Elems))
+		for _, el := range e.Elems {
+			v, err := p.Eval(el)
+			if err != nil {
+				return value.Value{}, err
+			}
+			elems = append(elems, v)
+		}
+		return value.OfArray(elems), nil
+	case *ast.CallExpr:
+		return p.evalCall(e)
+	default:
+		return value.Value{}, newErr(expr.Pos(), ErrInvalidExpression, "неизвестный узел выражения")
+	}
+}
+
+func (p *Parser) evalCall(c *ast.CallExpr) (value.Value, error) {
+	switch c.Op {
+	case "+":
+		return p.evalBinaryInt(c, func(a, b int64) (int64, error) { return a + b, nil })
+	case "-":
+		return p.evalBinaryInt(c, func(a, b int64) (int64, error) { return a - b, nil })
+	case "*":
+		return p.evalBinaryInt(c, func(a, b int64) (int64, error) { return a * b, nil })
+	case "/":
+		return p.evalBinaryInt(c, func(a, b int64) (int64, error) {
+			if b == 0 {
+				return 0, ErrDivisionByZero
+			}
+			return a / b, nil
+		})
+	case "mod":
+		return p.evalBinaryInt(c, func(a, b int64) (int64, error) {
+			if b == 0 {
+				return 0, ErrDivisionByZero
+			}
+			return a % b, nil
+		})
+	case "min":
+		return p.evalBinaryInt(c, func(a, b int64) (int64, error) {
+			if a < b {
+				return a, nil
+			}
+			return b, nil
+		})
+	case "max":
+		return p.evalBinaryInt(c, func(a, b int64) (int64, error) {
+			if a > b {
+				return a, nil
+			}
+			return b, nil
+		})
+	case "ord":
+		return p.evalOrd(c)
+	case "chr":
+		return p.evalChr(c)
+	case "abs":
+		return p.evalAbs(c)
+	case "len":
+		return p.evalLen(c)
+	case "concat":
+		return p.evalConcat(c)
+	default:
+		return value.Value{}, newErr(c.ExprPos, ErrInvalidExpression, "неизвестная операция: "+c.Op)
+	}
+}
+
+func (p *Parser) evalBinaryInt(c *ast.CallExpr, fn func(a, b int64) (int64, error)) (value.Value, error) {
+	if len(c.Args) != 2 {
+		return value.Value{}, newErr(c.ExprPos, ErrArity, c.Op+" требует ровно 2 аргумента")
+	}
+	a, err := p.evalInt(c.Args[0])
+	if err != nil {
+		return value.Value{}, err
+	}
+	b, err := p.evalInt(c.Args[1])
+	if err != nil {
+		return value.Value{}, err
+	}
+	r, err := fn(a, b)
+	if err != nil {
+		return value.Value{}, newErr(c.ExprPos, err, c.Op)
+	}
+	return value.Of(r), nil
+}
+
+func (p *Parser) evalOrd(c *ast.CallExpr) (value.Value, error) {
+	if len(c.Args) != 1 {
+		return value.Value{}, newErr(c.ExprPos, ErrArity, "ord требует ровно 1 аргумент")
+	}
+	s, err := p.evalString(c.Args[0])
+	if err != nil {
+		return value.Value{}, err
+	}
+	if len(s) == 0 {
+		return value.Value{}, newErr(c.ExprPos, ErrInvalidExpression, "ord: строка не может быть пустой")
+	}
+	return value.Of(int64(s[0])), nil
+}
+
+// evalChr is the inverse of ord: it turns an integer into the
+// single-character string with that byte value.
+func (p *Parser) evalChr(c *ast.CallExpr) (value.Value, error) {
+	if len(c.Args) != 1 {
+		return value.Value{}, newErr(c.ExprPos, ErrArity, "chr требует ровно 1 аргумент")
+	}
+	n, err := p.evalInt(c.Args[0])
+	if err != nil {
+		return value.Value{}, err
+	}
+	if n < 0 || n > 255 {
+		return value.Value{}, newErr(c.ExprPos, ErrInvalidExpression, "chr: значение должно быть в диапазоне 0..255")
+	}
+	return value.OfString(string([]byte{byte(n)})), nil
+}
+
+func (p *Parser) evalAbs(c *ast.CallExpr) (value.Value, error) {
+	if len(c.Args) != 1 {
+		return value.Value{}, newErr(c.ExprPos, ErrArity, "abs требует ровно 1 аргумент")
+	}
+	n, err := p.evalInt(c.Args[0])
+	if err != nil {
+		return value.Value{}, err
+	}
+	if n < 0 {
+		n = -n
+	}
+	return value.Of(n), nil
+}
+
+// evalLen returns the length of a string or array value.
+func (p *Parser) evalLen(c *ast.CallExpr) (value.Value, error) {
+	if len(c.Args) != 1 {
+		return value.Value{}, newErr(c.ExprPos, ErrArity, "len требует ровно 1 аргумент")
+	}
+	v, err := p.Eval(c.Args[0])
+	if err != nil {
+		return value.Value{}, err
+	}
+	switch v.Kind {
+	case value.String:
+		return value.Of(int64(len(v.Str))), nil
+	case value.Array:
+		return value.Of(int64(len(v.Array))), nil
+	default:
+		return value.Value{}, newErr(c.Args[0].Pos(), ErrInvalidExpression, "len: ожидалась строка или массив")
+	}
+}
+
+// evalConcat concatenates two or more string arguments.
+func (p *Parser) evalConcat(c *ast.CallExpr) (value.Value, error) {
+	if len(c.Args) < 2 {
+		return value.Value{}, newErr(c.ExprPos, ErrArity, "concat требует минимум 2 аргумента")
+	}
+	var sb strings.Builder
+	for _, arg := range c.Args {
+		s, err := p.evalString(arg)
+		if err != nil {
+			return value.Value{}, err
+		}
+		sb.WriteString(s)
+	}
+	return value.OfString(sb.String()), nil
+}
+
+// evalInt evaluates expr and requires the result to be an integer.
+func (p *Parser) evalInt(expr ast.Expr) (int64, error) {
+	v, err := p.Eval(expr)
+	if err != nil {
+		return 0, err
+	}
+	if v.Kind != value.Int {
+		return 0, newErr(expr.Pos(), ErrInvalidExpression, "ожидалось число")
+	}
+	return v.Int, nil
+}
+
+// evalString evaluates expr and requires the result to be a string.
+func (p *Parser) evalString(expr ast.Expr) (string, error) {
+	v, err := p.Eval(expr)
+	if err != nil {
+		return "", err
+	}
+	if v.Kind != value.String {
+		return "", newErr(expr.Pos(), ErrInvalidExpression, "ожидалась строка")
+	}
+	return v.Str, nil
+}
+
+// parseIntLiteral parses the literal text of a NumberLit/OCT_INT token,
+// including an optional leading sign.
+func parseIntLiteral(lit string, octal bool) (int64, error) {
+	sign := int64(1)
+	if len(lit) > 0 && (lit[0] == '+' || lit[0] == '-') {
+		if lit[0] == '-' {
+			sign = -1
+		}
+		lit = lit[1:]
+	}
+	if octal {
+		lit = lit[2:] // strip "0o"/"0O"
+		n, err := strconv.ParseInt(lit, 8, 64)
+		return sign * n, err
+	}
+	n, err := strconv.ParseInt(lit, 10, 64)
+	return sign * n, err
+}
@@ -0,0 +1,103 @@
+package parser
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/SlxvkVel/Confighomework/ast"
+)
+
+// FileOpener opens a configuration file by path. Supplying one via
+// WithFileOpener lets tests and embedded uses resolve includes against a
+// virtual filesystem instead of the OS one.
+type FileOpener func(path string) (io.ReadCloser, error)
+
+func osOpener(path string) (io.ReadCloser, error) {
+	return os.Open(path)
+}
+
+func (p *Parser) fileOpener() FileOpener {
+	if p.opener != nil {
+		return p.opener
+	}
+	return osOpener
+}
+
+func (p *Parser) readFile(path string) ([]byte, error) {
+	rc, err := p.fileOpener()(path)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// LoadFile reads path, recursively resolving and splicing any `include`
+// directives it contains, and returns the fully merged *ast.File. It
+// does not evaluate any expressions.
+func (p *Parser) LoadFile(path string) (*ast.File, error) {
+	p.includeStack = make(map[string]bool)
+	p.included = nil
+
+	start := path
+	if p.baseDir != "" && !filepath.IsAbs(path) {
+		start = filepath.Join(p.baseDir, path)
+	}
+	return p.loadFileRec(start)
+}
+
+// IncludedFiles returns the absolute paths of every file read by the
+// most recent LoadFile/Parse call, in the order they were opened (the
+// entry file first). A file included more than once appears once per
+// inclusion. Used by Watch to know what to subscribe to.
+func (p *Parser) IncludedFiles() []string {
+	return p.included
+}
+
+func (p *Parser) loadFileRec(path string) (*ast.File, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.includeStack[abs] {
+		return nil, newErr(Position{Filename: abs}, ErrIncludeCycle, abs)
+	}
+	p.includeStack[abs] = true
+	defer delete(p.includeStack, abs)
+
+	src, err := p.readFile(abs)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := ParseFile(abs, src)
+	if err != nil {
+		return nil, err
+	}
+	p.included = append(p.included, abs)
+
+	dir := filepath.Dir(abs)
+	expanded := make([]ast.Decl, 0, len(file.Decls))
+	for _, decl := range file.Decls {
+		inc, ok := decl.(*ast.IncludeDecl)
+		if !ok {
+			expanded = append(expanded, decl)
+			continue
+		}
+
+		incPath := inc.Path
+		if !filepath.IsAbs(incPath) {
+			incPath = filepath.Join(dir, incPath)
+		}
+		incFile, err := p.loadFileRec(incPath)
+		if err != nil {
+			return nil, err
+		}
+		expanded = append(expanded, incFile.Decls...)
+	}
+	file.Decls = expanded
+
+	return file, nil
+}
@@ -0,0 +1,67 @@
+package parser
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/SlxvkVel/Confighomework/toml"
+)
+
+// memOpener serves file contents from an in-memory map, so include
+// resolution can be tested without touching the filesystem.
+func memOpener(files map[string]string) FileOpener {
+	return func(path string) (io.ReadCloser, error) {
+		src, ok := files[path]
+		if !ok {
+			return nil, errors.New("file not found: " + path)
+		}
+		return io.NopCloser(bytes.NewReader([]byte(src))), nil
+	}
+}
+
+func TestLoadFileResolvesIncludes(t *testing.T) {
+	files := map[string]string{
+		"/cfg/main.conf": `
+BASE := 10;
+include @"common.conf";
+
+[app]
+total = TOTAL
+`,
+		"/cfg/common.conf": `
+EXTRA := 5;
+TOTAL := ?(+ BASE EXTRA);
+`,
+	}
+
+	p := NewParser(WithFileOpener(memOpener(files)))
+	file, err := p.LoadFile("/cfg/main.conf")
+	if err != nil {
+		t.Fatalf("LoadFile: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := toml.NewEmitter().Emit(&buf, file, p); err != nil {
+		t.Fatalf("Emit: %v", err)
+	}
+
+	want := "[app]\ntotal = 15\n"
+	if buf.String() != want {
+		t.Errorf("Ожидалось:\n%s\nНо получено:\n%s", want, buf.String())
+	}
+}
+
+func TestLoadFileDetectsIncludeCycle(t *testing.T) {
+	files := map[string]string{
+		"/cfg/a.conf": `include @"b.conf";`,
+		"/cfg/b.conf": `include @"a.conf";`,
+	}
+
+	p := NewParser(WithFileOpener(memOpener(files)))
+	_, err := p.LoadFile("/cfg/a.conf")
+	if !errors.Is(err, ErrIncludeCycle) {
+		t.Errorf("ожидалась ErrIncludeCycle, получено: %v", err)
+	}
+}
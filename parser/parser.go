@@ -0,0 +1,306 @@
+// Package parser turns configuration source into an *ast.File and, via
+// Parser, evaluates it and emits TOML. It replaces the original
+// line-by-line heuristics with a conventional scan -> parse -> emit
+// pipeline modeled on go/parser: scanner produces tokens, parser builds
+// an AST, and a separate toml.Emitter walks the AST to produce output.
+package parser
+
+import (
+	"github.com/SlxvkVel/Confighomework/ast"
+	"github.com/SlxvkVel/Confighomework/scanner"
+	"github.com/SlxvkVel/Confighomework/token"
+	"github.com/SlxvkVel/Confighomework/value"
+)
+
+// Position is re-exported so callers of this package don't also need to
+// import token just to read an error's location.
+type Position = token.Position
+
+// Parser holds the variable scope accumulated while evaluating a
+// configuration file (and, across Parse calls, for as long as the
+// Parser value is reused), plus the state needed to resolve `include`
+// directives: a base directory, a pluggable file opener, and cycle
+// detection for the file currently being loaded.
+type Parser struct {
+	variables map[string]value.Value
+
+	baseDir      string
+	opener       FileOpener
+	includeStack map[string]bool
+	included     []string
+}
+
+// Option configures a Parser constructed via NewParser.
+type Option func(*Parser)
+
+// WithBaseDir sets the directory relative-path inputs to Parse/LoadFile
+// are resolved against. Includes within those files are always resolved
+// relative to the file containing the include directive, regardless of
+// this setting.
+func WithBaseDir(dir string) Option {
+	return func(p *Parser) { p.baseDir = dir }
+}
+
+// WithFileOpener overrides how the parser reads files, letting tests and
+// embedded uses supply a virtual filesystem instead of the OS one.
+func WithFileOpener(opener FileOpener) Option {
+	return func(p *Parser) { p.opener = opener }
+}
+
+// NewParser creates a new Parser with an empty variable scope.
+func NewParser(opts ...Option) *Parser {
+	p := &Parser{variables: make(map[string]value.Value)}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// fileParser builds an *ast.File from a token stream. It knows nothing
+// about variable values; that's the evaluator's job.
+type fileParser struct {
+	sc     *scanner.Scanner
+	tok    token.Token
+	peeked *token.Token
+}
+
+func newFileParser(filename string, src []byte) *fileParser {
+	fp := &fileParser{sc: scanner.New(filename, src)}
+	fp.next()
+	return fp
+}
+
+func (fp *fileParser) scan() token.Token {
+	t := fp.sc.Scan()
+	for t.Type == token.COMMENT {
+		t = fp.sc.Scan()
+	}
+	return t
+}
+
+func (fp *fileParser) next() {
+	if fp.peeked != nil {
+		fp.tok = *fp.peeked
+		fp.peeked = nil
+		return
+	}
+	fp.tok = fp.scan()
+}
+
+// peek reports the token after fp.tok without consuming it, caching it so
+// the following next() doesn't rescan.
+func (fp *fileParser) peek() token.Token {
+	if fp.peeked == nil {
+		t := fp.scan()
+		fp.peeked = &t
+	}
+	return *fp.peeked
+}
+
+// ParseFile scans and parses src, named filename for error reporting,
+// into an *ast.File. It does not evaluate any expressions.
+func ParseFile(filename string, src []byte) (*ast.File, error) {
+	fp := newFileParser(filename, src)
+	file := &ast.File{Name: filename}
+
+	for fp.tok.Type != token.EOF {
+		decl, err := fp.parseDecl()
+		if err != nil {
+			return nil, err
+		}
+		if decl != nil {
+			file.Decls = append(file.Decls, decl)
+		}
+	}
+	return file, nil
+}
+
+func (fp *fileParser) parseDecl() (ast.Decl, error) {
+	switch fp.tok.Type {
+	case token.LBRACKET:
+		return fp.parseSectionHeader()
+	case token.IDENT:
+		return fp.parseIdentDecl()
+	default:
+		return nil, newErr(fp.tok.Pos, ErrInvalidSyntax, "неожиданный токен "+fp.tok.Type.String())
+	}
+}
+
+func (fp *fileParser) parseIncludeDecl(pos token.Position) (ast.Decl, error) {
+	fp.next() // 'include'
+	if fp.tok.Type != token.STRING {
+		return nil, newErr(fp.tok.Pos, ErrInvalidSyntax, "ожидался путь включаемого файла")
+	}
+	path := fp.tok.Literal
+	fp.next()
+	if fp.tok.Type != token.SEMICOLON {
+		return nil, newErr(fp.tok.Pos, ErrInvalidSyntax, "ожидалось ';'")
+	}
+	fp.next()
+	return &ast.IncludeDecl{Path: path, DeclPos: pos}, nil
+}
+
+func (fp *fileParser) parseSectionHeader() (ast.Decl, error) {
+	pos := fp.tok.Pos
+	fp.next() // '['
+	if fp.tok.Type != token.IDENT {
+		return nil, newErr(fp.tok.Pos, ErrInvalidSyntax, "ожидалось имя секции")
+	}
+	name := fp.tok.Literal
+	fp.next()
+	if fp.tok.Type != token.RBRACKET {
+		return nil, newErr(fp.tok.Pos, ErrInvalidSyntax, "ожидалось ']'")
+	}
+	fp.next()
+	return &ast.SectionHeader{Name: name, DeclPos: pos}, nil
+}
+
+const includeKeyword = "include"
+
+func (fp *fileParser) parseIdentDecl() (ast.Decl, error) {
+	pos := fp.tok.Pos
+	name := fp.tok.Literal
+
+	if name == includeKeyword && fp.peek().Type == token.STRING {
+		return fp.parseIncludeDecl(pos)
+	}
+
+	fp.next()
+
+	switch fp.tok.Type {
+	case token.ASSIGN:
+		if !isValidConstName(name) {
+			return nil, newErr(pos, ErrInvalidSyntax, "неверное имя переменной '"+name+"': должны быть только заглавные буквы и подчеркивания")
+		}
+		fp.next()
+		expr, err := fp.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if fp.tok.Type != token.SEMICOLON {
+			return nil, newErr(fp.tok.Pos, ErrInvalidSyntax, "ожидалось ';'")
+		}
+		fp.next()
+		return &ast.ConstDecl{Name: name, Value: expr, DeclPos: pos}, nil
+
+	case token.EQ:
+		fp.next()
+		expr, err := fp.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		return &ast.KeyValue{Key: name, Value: expr, DeclPos: pos}, nil
+
+	default:
+		return nil, newErr(fp.tok.Pos, ErrInvalidSyntax, "ожидалось ':=' или '='")
+	}
+}
+
+func (fp *fileParser) parseExpr() (ast.Expr, error) {
+	switch fp.tok.Type {
+	case token.INT, token.OCT_INT:
+		return fp.parseNumberLit()
+	case token.STRING:
+		lit := &ast.StringLit{Value: fp.tok.Literal, ExprPos: fp.tok.Pos}
+		fp.next()
+		return lit, nil
+	case token.IDENT:
+		id := &ast.Ident{Name: fp.tok.Literal, ExprPos: fp.tok.Pos}
+		fp.next()
+		return id, nil
+	case token.LBRACKET:
+		return fp.parseArrayLit()
+	case token.QLPAREN:
+		return fp.parseCallExpr()
+	default:
+		return nil, newErr(fp.tok.Pos, ErrInvalidSyntax, "ожидалось значение")
+	}
+}
+
+func (fp *fileParser) parseNumberLit() (ast.Expr, error) {
+	octal := fp.tok.Type == token.OCT_INT
+	n, err := parseIntLiteral(fp.tok.Literal, octal)
+	if err != nil {
+		return nil, newErr(fp.tok.Pos, ErrInvalidSyntax, err.Error())
+	}
+	lit := &ast.NumberLit{Value: n, Octal: octal, ExprPos: fp.tok.Pos}
+	fp.next()
+	return lit, nil
+}
+
+func (fp *fileParser) parseArrayLit() (ast.Expr, error) {
+	pos := fp.tok.Pos
+	fp.next() // '['
+	var elems []ast.Expr
+	for fp.tok.Type != token.RBRACKET {
+		elem, err := fp.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		elems = append(elems, elem)
+		if fp.tok.Type == token.COMMA {
+			fp.next()
+			continue
+		}
+		break
+	}
+	if fp.tok.Type != token.RBRACKET {
+		return nil, newErr(fp.tok.Pos, ErrInvalidSyntax, "ожидалось ']'")
+	}
+	fp.next()
+	return &ast.ArrayLit{Elems: elems, ExprPos: pos}, nil
+}
+
+// opToken reports the textual operator for tokens that lex separately
+// from IDENT (+, -, *, /), and whether tok is usable as an operator.
+func opToken(tok token.Token) (string, bool) {
+	switch tok.Type {
+	case token.ADD, token.SUB, token.MUL, token.QUO, token.IDENT:
+		return tok.Literal, true
+	default:
+		return "", false
+	}
+}
+
+func (fp *fileParser) parseCallExpr() (ast.Expr, error) {
+	pos := fp.tok.Pos
+	fp.next() // '?('
+
+	op, ok := opToken(fp.tok)
+	if !ok {
+		return nil, newErr(fp.tok.Pos, ErrInvalidExpression, "ожидался оператор")
+	}
+	fp.next()
+
+	var args []ast.Expr
+	for fp.tok.Type != token.RPAREN {
+		arg, err := fp.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	fp.next() // ')'
+
+	return &ast.CallExpr{Op: op, Args: args, ExprPos: pos}, nil
+}
+
+func isValidConstName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !(r >= 'A' && r <= 'Z') && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// Parse reads inputPath, evaluates it and writes the resulting TOML to
+// outputPath, leaving outputPath untouched if evaluation fails partway
+// through. It is a thin wrapper around writeOnce kept for back-compat
+// with the original API.
+func (p *Parser) Parse(inputPath, outputPath string) error {
+	return p.writeOnce(inputPath, outputPath)
+}
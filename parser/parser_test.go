@@ -0,0 +1,282 @@
+package parser
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func parseToString(t *testing.T, src string) string {
+	t.Helper()
+
+	inputFile, err := os.CreateTemp("", "test_*.conf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(inputFile.Name())
+
+	outputFile, err := os.CreateTemp("", "test_*.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(outputFile.Name())
+
+	if _, err := inputFile.WriteString(src); err != nil {
+		t.Fatal(err)
+	}
+	inputFile.Close()
+
+	p := NewParser()
+	if err := p.Parse(inputFile.Name(), outputFile.Name()); err != nil {
+		t.Fatalf("Ошибка парсинга: %v", err)
+	}
+
+	out, err := os.ReadFile(outputFile.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func TestParseConstants(t *testing.T) {
+	parseToString(t, `
+// Тест констант
+PORT := 8080;
+HOST := @"localhost";
+OCTAL := 0o755;
+EXPRESSION := ?(+ 10 20);
+
+[test]
+port = PORT
+host = HOST
+octal = OCTAL
+expr = EXPRESSION
+`)
+}
+
+func TestParseArrays(t *testing.T) {
+	got := parseToString(t, `
+HOSTS := [ @"host1", @"host2", @"host3" ];
+PORTS := [ 80, 443, 8080 ];
+
+[network]
+hosts = HOSTS
+ports = PORTS
+`)
+
+	want := "[network]\n" +
+		`hosts = ["host1", "host2", "host3"]` + "\n" +
+		"ports = [80, 443, 8080]\n"
+	if got != want {
+		t.Errorf("Ожидалось:\n%s\nНо получено:\n%s", want, got)
+	}
+}
+
+func TestParseBooleans(t *testing.T) {
+	got := parseToString(t, `
+[app]
+enabled = true
+debug = false
+flags = [ true, false ]
+`)
+
+	want := "[app]\n" +
+		"enabled = true\n" +
+		"debug = false\n" +
+		"flags = [true, false]\n"
+	if got != want {
+		t.Errorf("Ожидалось:\n%s\nНо получено:\n%s", want, got)
+	}
+}
+
+func TestParseIncludeAsOrdinaryIdent(t *testing.T) {
+	got := parseToString(t, `
+[server]
+include = true
+`)
+
+	want := "[server]\n" + "include = true\n"
+	if got != want {
+		t.Errorf("Ожидалось:\n%s\nНо получено:\n%s", want, got)
+	}
+}
+
+func TestParseExpressions(t *testing.T) {
+	got := parseToString(t, `
+A := 10;
+B := 5;
+SUM := ?(+ A B);
+DIFF := ?(- A B);
+CHAR_CODE := ?(ord @"Z");
+ABS_VAL := ?(abs -15);
+
+[calculations]
+sum = SUM
+diff = DIFF
+char_code = CHAR_CODE
+abs_val = ABS_VAL
+`)
+
+	want := "[calculations]\n" +
+		"sum = 15\n" +
+		"diff = 5\n" +
+		"char_code = 90\n" +
+		"abs_val = 15\n"
+	if got != want {
+		t.Errorf("Ожидалось:\n%s\nНо получено:\n%s", want, got)
+	}
+}
+
+func TestParseNewBuiltins(t *testing.T) {
+	got := parseToString(t, `
+A := 6;
+B := 7;
+PRODUCT := ?(* A B);
+QUOTIENT := ?(/ A B);
+REMAINDER := ?(mod A B);
+SMALLEST := ?(min A B);
+LARGEST := ?(max A B);
+LETTER := ?(chr 90);
+GREETING := ?(concat @"hello, " @"world");
+NESTED := ?(+ ?(* 2 3) ?(- 10 4));
+NAMES := [ @"a", @"bb", @"ccc" ];
+COUNT := ?(len NAMES);
+
+[builtins]
+product = PRODUCT
+quotient = QUOTIENT
+remainder = REMAINDER
+smallest = SMALLEST
+largest = LARGEST
+letter = LETTER
+greeting = GREETING
+nested = NESTED
+count = COUNT
+`)
+
+	want := "[builtins]\n" +
+		"product = 42\n" +
+		"quotient = 0\n" +
+		"remainder = 6\n" +
+		"smallest = 6\n" +
+		"largest = 7\n" +
+		`letter = "Z"` + "\n" +
+		`greeting = "hello, world"` + "\n" +
+		"nested = 12\n" +
+		"count = 3\n"
+	if got != want {
+		t.Errorf("Ожидалось:\n%s\nНо получено:\n%s", want, got)
+	}
+}
+
+func TestParseDivisionByZero(t *testing.T) {
+	p := NewParser()
+
+	inputFile, err := os.CreateTemp("", "test_divzero.conf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(inputFile.Name())
+
+	outputFile, err := os.CreateTemp("", "test_divzero.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(outputFile.Name())
+
+	if _, err := inputFile.WriteString(`BAD := ?(/ 1 0);`); err != nil {
+		t.Fatal(err)
+	}
+	inputFile.Close()
+
+	err = p.Parse(inputFile.Name(), outputFile.Name())
+	if !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("ожидалась ErrDivisionByZero, получено: %v", err)
+	}
+}
+
+func TestParseLeavesOutputUntouchedOnFailure(t *testing.T) {
+	dir := t.TempDir()
+	inputPath := filepath.Join(dir, "app.conf")
+	outputPath := filepath.Join(dir, "app.toml")
+
+	if err := os.WriteFile(outputPath, []byte("[app]\nport = 8080\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// References an undefined variable partway through the file, so
+	// evaluation fails after the emitter has already produced output for
+	// the section above it.
+	if err := os.WriteFile(inputPath, []byte("[app]\nport = 8080\nhost = MISSING\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	p := NewParser()
+	if err := p.Parse(inputPath, outputPath); err == nil {
+		t.Fatal("ожидалась ошибка парсинга")
+	}
+
+	got, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "[app]\nport = 8080\n" {
+		t.Errorf("output was truncated on a failed parse: %q", got)
+	}
+}
+
+func TestParseMultilineComments(t *testing.T) {
+	got := parseToString(t, `
+{-
+ Этот комментарий
+ должен быть полностью
+ проигнорирован
+-}
+VALUE := 42;
+
+[section]
+key = VALUE
+`)
+
+	want := "[section]\nkey = 42\n"
+	if got != want {
+		t.Errorf("Ожидалось:\n%s\nНо получено:\n%s", want, got)
+	}
+}
+
+func TestParseInvalidName(t *testing.T) {
+	p := NewParser()
+
+	inputFile, err := os.CreateTemp("", "test_invalid.conf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(inputFile.Name())
+
+	outputFile, err := os.CreateTemp("", "test_invalid.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(outputFile.Name())
+
+	if _, err := inputFile.WriteString(`invalid_name := 42;`); err != nil {
+		t.Fatal(err)
+	}
+	inputFile.Close()
+
+	if err := p.Parse(inputFile.Name(), outputFile.Name()); err == nil {
+		t.Error("Ожидалась ошибка для невалидного имени переменной")
+	}
+}
+
+func TestParseValidNamesWithUnderscore(t *testing.T) {
+	parseToString(t, `
+// Имена с подчеркиванием должны быть валидны
+MY_VAR := 10;
+ANOTHER_VAR := 20;
+_RESULT := ?(+ MY_VAR ANOTHER_VAR);
+
+[test]
+value = _RESULT
+`)
+}
@@ -0,0 +1,159 @@
+package parser
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/SlxvkVel/Confighomework/toml"
+	"github.com/SlxvkVel/Confighomework/value"
+)
+
+const watchDebounce = 100 * time.Millisecond
+
+// Watch parses inputPath once, writes outputPath, then keeps re-parsing
+// on every change to inputPath (and, once resolved, to any file it
+// includes) until ctx is cancelled. Each re-parse writes to a temp file
+// and atomically renames it over outputPath, so outputPath always holds
+// either the initial output or the last successful re-parse: a failing
+// parse is reported via onError, if non-nil, and leaves outputPath
+// untouched.
+func (p *Parser) Watch(ctx context.Context, inputPath, outputPath string, onError func(error)) error {
+	if onError == nil {
+		onError = func(error) {}
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	// reload touches p.variables and, through writeOnce, every field the
+	// evaluator reads and writes while folding constants. It must only
+	// ever run on the select loop's goroutine below, never concurrently
+	// with itself, so the debounce timer signals a reload through
+	// trigger instead of calling reload directly from its own goroutine.
+	reload := func() {
+		p.variables = make(map[string]value.Value)
+		if err := p.writeOnce(inputPath, outputPath); err != nil {
+			onError(err)
+		}
+		if err := syncWatchedFiles(watcher, watchedPaths(p, inputPath)); err != nil {
+			onError(err)
+		}
+	}
+
+	reload()
+
+	trigger := make(chan struct{}, 1)
+	requestReload := func() {
+		select {
+		case trigger <- struct{}{}:
+		default:
+		}
+	}
+
+	var debounce *time.Timer
+	for {
+		select {
+		case <-ctx.Done():
+			if debounce != nil {
+				debounce.Stop()
+			}
+			return nil
+
+		case _, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if debounce != nil {
+				debounce.Stop()
+			}
+			debounce = time.AfterFunc(watchDebounce, requestReload)
+
+		case <-trigger:
+			reload()
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			onError(err)
+		}
+	}
+}
+
+// writeOnce parses inputPath and writes its output to a temp file next
+// to outputPath, then renames it into place so readers never observe a
+// partial write.
+func (p *Parser) writeOnce(inputPath, outputPath string) error {
+	file, err := p.LoadFile(inputPath)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(outputPath), ".confighomework-*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	if err := toml.NewEmitter().Emit(tmp, file, p); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	return os.Rename(tmpPath, outputPath)
+}
+
+// watchedPaths returns the files that should be watched after a reload:
+// whatever the parser actually resolved (empty if even inputPath itself
+// couldn't be parsed), plus inputPath itself unconditionally, so a
+// failed reload still leaves inputPath subscribed and editing it away
+// from the error is noticed.
+func watchedPaths(p *Parser, inputPath string) []string {
+	paths := p.IncludedFiles()
+
+	absInput, err := filepath.Abs(inputPath)
+	if err != nil {
+		absInput = inputPath
+	}
+	for _, path := range paths {
+		if path == absInput {
+			return paths
+		}
+	}
+	return append(paths, absInput)
+}
+
+// syncWatchedFiles makes watcher track exactly the given paths,
+// dropping anything it was watching that's no longer in the set. This
+// keeps the watch list current as includes are added or removed.
+func syncWatchedFiles(watcher *fsnotify.Watcher, paths []string) error {
+	want := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		want[path] = true
+	}
+
+	for _, path := range watcher.WatchList() {
+		if !want[path] {
+			watcher.Remove(path)
+		}
+	}
+
+	for path := range want {
+		if err := watcher.Add(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package parser
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWatchReloadsOnChange(t *testing.T) {
+	dir := t.TempDir()
+	inputPath := filepath.Join(dir, "app.conf")
+	outputPath := filepath.Join(dir, "app.toml")
+
+	if err := os.WriteFile(inputPath, []byte("VALUE := 1;\n\n[app]\nvalue = VALUE\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p := NewParser()
+	done := make(chan error, 1)
+	go func() { done <- p.Watch(ctx, inputPath, outputPath, nil) }()
+
+	waitForContent(t, outputPath, "[app]\nvalue = 1\n")
+
+	if err := os.WriteFile(inputPath, []byte("VALUE := 2;\n\n[app]\nvalue = VALUE\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	waitForContent(t, outputPath, "[app]\nvalue = 2\n")
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+}
+
+func TestWatchRecoversFromInitialParseError(t *testing.T) {
+	dir := t.TempDir()
+	inputPath := filepath.Join(dir, "app.conf")
+	outputPath := filepath.Join(dir, "app.toml")
+
+	// References an undefined variable, so the initial parse fails.
+	if err := os.WriteFile(inputPath, []byte("[app]\nvalue = MISSING\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var errCount int32
+	p := NewParser()
+	done := make(chan error, 1)
+	go func() {
+		done <- p.Watch(ctx, inputPath, outputPath, func(error) {
+			atomic.AddInt32(&errCount, 1)
+		})
+	}()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for atomic.LoadInt32(&errCount) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if atomic.LoadInt32(&errCount) == 0 {
+		t.Fatal("expected OnError to fire for the initial parse failure")
+	}
+
+	if err := os.WriteFile(inputPath, []byte("VALUE := 1;\n\n[app]\nvalue = VALUE\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	waitForContent(t, outputPath, "[app]\nvalue = 1\n")
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+}
+
+// TestWatchRapidChangesDoNotRace exercises the path `go test -race`
+// checks: a burst of writes within the debounce window used to fire
+// reload directly from the debounce timer's own goroutine, racing with
+// a reload already in flight on the select loop's goroutine.
+func TestWatchRapidChangesDoNotRace(t *testing.T) {
+	dir := t.TempDir()
+	inputPath := filepath.Join(dir, "app.conf")
+	outputPath := filepath.Join(dir, "app.toml")
+
+	if err := os.WriteFile(inputPath, []byte("VALUE := 0;\n\n[app]\nvalue = VALUE\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p := NewParser()
+	done := make(chan error, 1)
+	go func() { done <- p.Watch(ctx, inputPath, outputPath, nil) }()
+
+	waitForContent(t, outputPath, "[app]\nvalue = 0\n")
+
+	for i := 1; i <= 20; i++ {
+		src := []byte("VALUE := " + string(rune('0'+i%10)) + ";\n\n[app]\nvalue = VALUE\n")
+		if err := os.WriteFile(inputPath, src, 0644); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	final := "VALUE := 99;\n\n[app]\nvalue = VALUE\n"
+	if err := os.WriteFile(inputPath, []byte(final), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	waitForContent(t, outputPath, "[app]\nvalue = 99\n")
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+}
+
+func waitForContent(t *testing.T, path, want string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		got, err := os.ReadFile(path)
+		if err == nil && string(got) == want {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %s to contain %q", path, want)
+}
@@ -0,0 +1,218 @@
+// Package scanner turns configuration source text into a stream of
+// token.Token values, tracking line/column positions for diagnostics.
+package scanner
+
+import (
+	"unicode/utf8"
+
+	"github.com/SlxvkVel/Confighomework/token"
+)
+
+// Scanner tokenizes a single source buffer.
+type Scanner struct {
+	filename string
+	src      []byte
+
+	ch       rune
+	offset   int
+	rdOffset int
+	line     int
+	column   int
+}
+
+// New creates a Scanner over src. filename is used only for position
+// reporting.
+func New(filename string, src []byte) *Scanner {
+	s := &Scanner{filename: filename, src: src, line: 1, column: 0}
+	s.next()
+	return s
+}
+
+const eof = -1
+
+func (s *Scanner) next() {
+	if s.rdOffset >= len(s.src) {
+		s.offset = len(s.src)
+		s.ch = eof
+		return
+	}
+	s.offset = s.rdOffset
+	if s.ch == '\n' {
+		s.line++
+		s.column = 0
+	}
+	r, w := utf8.DecodeRune(s.src[s.rdOffset:])
+	s.ch = r
+	s.rdOffset += w
+	s.column++
+}
+
+func (s *Scanner) peek() rune {
+	if s.rdOffset >= len(s.src) {
+		return eof
+	}
+	r, _ := utf8.DecodeRune(s.src[s.rdOffset:])
+	return r
+}
+
+func (s *Scanner) pos() token.Position {
+	return token.Position{Filename: s.filename, Line: s.line, Column: s.column}
+}
+
+func isLetter(ch rune) bool {
+	return ch == '_' || ('a' <= ch && ch <= 'z') || ('A' <= ch && ch <= 'Z')
+}
+
+func isDigit(ch rune) bool {
+	return '0' <= ch && ch <= '9'
+}
+
+// Scan returns the next token in the stream, ending with a token.EOF
+// token which is returned on every subsequent call.
+func (s *Scanner) Scan() token.Token {
+	s.skipWhitespace()
+
+	pos := s.pos()
+
+	switch {
+	case s.ch == eof:
+		return token.Token{Type: token.EOF, Pos: pos}
+	case s.ch == '/' && s.peek() == '/':
+		return s.scanLineComment(pos)
+	case s.ch == '{' && s.peek() == '-':
+		return s.scanBlockComment(pos)
+	case s.ch == '@' && s.peek() == '"':
+		return s.scanString(pos)
+	case isDigit(s.ch):
+		return s.scanNumber(pos, "")
+	case isLetter(s.ch):
+		return s.scanIdent(pos)
+	}
+
+	ch := s.ch
+	switch ch {
+	case ':':
+		if s.peek() == '=' {
+			s.next()
+			s.next()
+			return token.Token{Type: token.ASSIGN, Literal: ":=", Pos: pos}
+		}
+		s.next()
+		return token.Token{Type: token.ILLEGAL, Literal: ":", Pos: pos}
+	case '=':
+		s.next()
+		return token.Token{Type: token.EQ, Literal: "=", Pos: pos}
+	case ';':
+		s.next()
+		return token.Token{Type: token.SEMICOLON, Literal: ";", Pos: pos}
+	case ',':
+		s.next()
+		return token.Token{Type: token.COMMA, Literal: ",", Pos: pos}
+	case '[':
+		s.next()
+		return token.Token{Type: token.LBRACKET, Literal: "[", Pos: pos}
+	case ']':
+		s.next()
+		return token.Token{Type: token.RBRACKET, Literal: "]", Pos: pos}
+	case ')':
+		s.next()
+		return token.Token{Type: token.RPAREN, Literal: ")", Pos: pos}
+	case '?':
+		if s.peek() == '(' {
+			s.next()
+			s.next()
+			return token.Token{Type: token.QLPAREN, Literal: "?(", Pos: pos}
+		}
+		s.next()
+		return token.Token{Type: token.ILLEGAL, Literal: "?", Pos: pos}
+	case '+', '-':
+		if isDigit(s.peek()) {
+			s.next() // consume sign, it becomes part of the number literal
+			return s.scanNumber(pos, string(ch))
+		}
+		s.next()
+		if ch == '+' {
+			return token.Token{Type: token.ADD, Literal: "+", Pos: pos}
+		}
+		return token.Token{Type: token.SUB, Literal: "-", Pos: pos}
+	case '*':
+		s.next()
+		return token.Token{Type: token.MUL, Literal: "*", Pos: pos}
+	case '/':
+		s.next()
+		return token.Token{Type: token.QUO, Literal: "/", Pos: pos}
+	}
+
+	s.next()
+	return token.Token{Type: token.ILLEGAL, Literal: string(ch), Pos: pos}
+}
+
+func (s *Scanner) skipWhitespace() {
+	for s.ch == ' ' || s.ch == '\t' || s.ch == '\n' || s.ch == '\r' {
+		s.next()
+	}
+}
+
+func (s *Scanner) scanLineComment(pos token.Position) token.Token {
+	start := s.offset
+	for s.ch != '\n' && s.ch != eof {
+		s.next()
+	}
+	return token.Token{Type: token.COMMENT, Literal: string(s.src[start:s.offset]), Pos: pos}
+}
+
+func (s *Scanner) scanBlockComment(pos token.Position) token.Token {
+	start := s.offset
+	s.next() // '{'
+	s.next() // '-'
+	for {
+		if s.ch == eof {
+			break
+		}
+		if s.ch == '-' && s.peek() == '}' {
+			s.next()
+			s.next()
+			break
+		}
+		s.next()
+	}
+	return token.Token{Type: token.COMMENT, Literal: string(s.src[start:s.offset]), Pos: pos}
+}
+
+func (s *Scanner) scanString(pos token.Position) token.Token {
+	s.next() // '@'
+	s.next() // opening '"'
+	start := s.offset
+	for s.ch != '"' && s.ch != eof {
+		s.next()
+	}
+	lit := string(s.src[start:s.offset])
+	if s.ch == '"' {
+		s.next()
+	}
+	return token.Token{Type: token.STRING, Literal: lit, Pos: pos}
+}
+
+func (s *Scanner) scanNumber(pos token.Position, sign string) token.Token {
+	start := s.offset
+	if s.ch == '0' && (s.peek() == 'o' || s.peek() == 'O') {
+		s.next()
+		s.next()
+		for isDigit(s.ch) {
+			s.next()
+		}
+		return token.Token{Type: token.OCT_INT, Literal: sign + string(s.src[start:s.offset]), Pos: pos}
+	}
+	for isDigit(s.ch) {
+		s.next()
+	}
+	return token.Token{Type: token.INT, Literal: sign + string(s.src[start:s.offset]), Pos: pos}
+}
+
+func (s *Scanner) scanIdent(pos token.Position) token.Token {
+	start := s.offset
+	for isLetter(s.ch) || isDigit(s.ch) {
+		s.next()
+	}
+	return token.Token{Type: token.IDENT, Literal: string(s.src[start:s.offset]), Pos: pos}
+}
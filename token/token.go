@@ -0,0 +1,84 @@
+// Package token defines the lexical tokens of the configuration language
+// and the source positions used to report errors against them.
+package token
+
+import "fmt"
+
+// Type identifies the class of a lexical token.
+type Type int
+
+const (
+	ILLEGAL Type = iota
+	EOF
+	COMMENT
+
+	IDENT   // PORT, host, include
+	INT     // 8080
+	OCT_INT // 0o755
+	STRING  // @"localhost"
+
+	ASSIGN    // :=
+	EQ        // =
+	SEMICOLON // ;
+	COMMA     // ,
+	LBRACKET  // [
+	RBRACKET  // ]
+	QLPAREN   // ?(
+	RPAREN    // )
+
+	ADD // +
+	SUB // -
+	MUL // *
+	QUO // /
+)
+
+var names = map[Type]string{
+	ILLEGAL:   "ILLEGAL",
+	EOF:       "EOF",
+	COMMENT:   "COMMENT",
+	IDENT:     "IDENT",
+	INT:       "INT",
+	OCT_INT:   "OCT_INT",
+	STRING:    "STRING",
+	ASSIGN:    ":=",
+	EQ:        "=",
+	SEMICOLON: ";",
+	COMMA:     ",",
+	LBRACKET:  "[",
+	RBRACKET:  "]",
+	QLPAREN:   "?(",
+	RPAREN:    ")",
+	ADD:       "+",
+	SUB:       "-",
+	MUL:       "*",
+	QUO:       "/",
+}
+
+func (t Type) String() string {
+	if s, ok := names[t]; ok {
+		return s
+	}
+	return fmt.Sprintf("Type(%d)", int(t))
+}
+
+// Position identifies a location in a source file.
+type Position struct {
+	Filename string
+	Line     int
+	Column   int
+}
+
+func (p Position) String() string {
+	if p.Filename == "" {
+		return fmt.Sprintf("%d:%d", p.Line, p.Column)
+	}
+	return fmt.Sprintf("%s:%d:%d", p.Filename, p.Line, p.Column)
+}
+
+// Token is a single lexical token together with its literal text and
+// the position it started at.
+type Token struct {
+	Type    Type
+	Literal string
+	Pos     Position
+}
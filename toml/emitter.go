@@ -0,0 +1,64 @@
+// Package toml renders a parsed configuration file as TOML. It only
+// understands the AST shape produced by package parser: a flat stream
+// of constant declarations, section headers and key/value pairs.
+package toml
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/SlxvkVel/Confighomework/ast"
+	"github.com/SlxvkVel/Confighomework/value"
+)
+
+// Resolver evaluates expressions and accumulates constant bindings as
+// the emitter walks the AST in order. *parser.Parser implements this.
+type Resolver interface {
+	Eval(expr ast.Expr) (value.Value, error)
+	Define(name string, v value.Value)
+}
+
+// Emitter walks an *ast.File and writes the equivalent TOML to an
+// io.Writer.
+type Emitter struct{}
+
+// NewEmitter creates an Emitter.
+func NewEmitter() *Emitter {
+	return &Emitter{}
+}
+
+// Emit walks file in order, evaluating expressions through r and
+// writing `[section]` headers and `key = value` lines. ConstDecl and
+// IncludeDecl nodes contribute no output of their own: the former only
+// extends r's variable scope, and the latter has already been spliced
+// into file by the parser before Emit ever sees it.
+func (e *Emitter) Emit(w io.Writer, file *ast.File, r Resolver) error {
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.ConstDecl:
+			v, err := r.Eval(d.Value)
+			if err != nil {
+				return err
+			}
+			r.Define(d.Name, v)
+
+		case *ast.SectionHeader:
+			if _, err := fmt.Fprintf(w, "[%s]\n", d.Name); err != nil {
+				return err
+			}
+
+		case *ast.KeyValue:
+			v, err := r.Eval(d.Value)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(w, "%s = %s\n", d.Key, v.String()); err != nil {
+				return err
+			}
+
+		case *ast.IncludeDecl:
+			// Resolved by the parser prior to emission.
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+// Package value defines the runtime values produced by evaluating the
+// configuration language: integers, strings, booleans and arrays of
+// values.
+package value
+
+import "fmt"
+
+// Kind distinguishes the concrete type held by a Value.
+type Kind int
+
+const (
+	Int Kind = iota
+	String
+	Bool
+	Array
+)
+
+// Value is a tagged union over the four value kinds the language
+// produces. Only the field matching Kind is populated.
+type Value struct {
+	Kind  Kind
+	Int   int64
+	Str   string
+	Bool  bool
+	Array []Value
+}
+
+// Of wraps a Go int64 as an integer Value.
+func Of(i int64) Value { return Value{Kind: Int, Int: i} }
+
+// OfString wraps a Go string as a string Value.
+func OfString(s string) Value { return Value{Kind: String, Str: s} }
+
+// OfBool wraps a Go bool as a boolean Value.
+func OfBool(b bool) Value { return Value{Kind: Bool, Bool: b} }
+
+// OfArray wraps a slice of Values as an array Value.
+func OfArray(vs []Value) Value { return Value{Kind: Array, Array: vs} }
+
+// String renders the value the way it would appear on the right-hand
+// side of a TOML assignment, e.g. `"localhost"`, `8080`, `[1, 2]`.
+func (v Value) String() string {
+	switch v.Kind {
+	case Int:
+		return fmt.Sprintf("%d", v.Int)
+	case String:
+		return fmt.Sprintf("%q", v.Str)
+	case Bool:
+		return fmt.Sprintf("%t", v.Bool)
+	case Array:
+		out := "["
+		for i, e := range v.Array {
+			if i > 0 {
+				out += ", "
+			}
+			out += e.String()
+		}
+		return out + "]"
+	default:
+		return ""
+	}
+}